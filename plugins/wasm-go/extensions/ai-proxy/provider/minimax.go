@@ -1,6 +1,8 @@
 package provider
 
 import (
+	"bytes"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -25,9 +27,56 @@ const (
 	minimaxChatCompletionV2Path = "/v1/text/chatcompletion_v2"
 	// minimaxChatCompletionProPath represents the API path for chat completion Pro API which has a different response format from OpenAI's.
 	minimaxChatCompletionProPath = "/v1/text/chatcompletion_pro"
+	// minimaxTextToAudioPath represents the API path for the T2A v2 (text-to-audio) API.
+	minimaxTextToAudioPath = "/v1/t2a_v2"
+	// minimaxTextToAudioProPath represents the API path for the T2A pro API, an older, higher-quality tier of MiniMax TTS.
+	minimaxTextToAudioProPath = "/v1/t2a_pro"
+	// minimaxTextToAudioLargePath represents the API path for the T2A large API, MiniMax's highest-capacity TTS tier.
+	minimaxTextToAudioLargePath = "/v1/t2a_large"
+	// minimaxVoiceClonePath represents the API path for the voice cloning API.
+	minimaxVoiceClonePath = "/v1/voice_clone"
 
-	senderTypeUser string = "USER" // Content sent by the user.
-	senderTypeBot  string = "BOT"  // Content generated by the model.
+	// openaiAudioSpeechPath is the OpenAI-compatible path clients use to request text-to-speech.
+	openaiAudioSpeechPath = "/v1/audio/speech"
+
+	// OpenAI-compatible paths for the Assistants v2 surface, routed to MiniMax's Assistants/Files/Retrieval APIs.
+	openaiAssistantsPath = "/v1/assistants"
+	openaiThreadsPath    = "/v1/threads"
+	openaiFilesPath      = "/v1/files"
+	openaiRetrievalPath  = "/v1/retrieval"
+
+	// openaiFineTuningJobsPath is the OpenAI-compatible path for fine-tuning job management; MiniMax's own
+	// Finetune API lives under minimaxFinetuneJobPath.
+	openaiFineTuningJobsPath = "/v1/fine_tuning/jobs"
+	minimaxFinetuneJobPath   = "/v1/finetune/job"
+
+	// openaiModerationsPath is the OpenAI-compatible path MiniMax's Role Classification API is exposed under.
+	openaiModerationsPath = "/v1/moderations"
+	// minimaxRoleClassifyPath is MiniMax's own Role Classification API path.
+	minimaxRoleClassifyPath = "/v1/text/role_classify"
+	// minimaxRoleAudioPath is MiniMax's own Role Audio Generation API path.
+	minimaxRoleAudioPath = "/v1/t2a_role"
+	// openaiAudioSpeechRolePath is the OpenAI-compatible path clients use to reach Role Audio Generation: the
+	// same /v1/audio/speech schema extended with character_id/emotion, under a "/role" sub-path so it doesn't
+	// collide with plain text-to-speech.
+	openaiAudioSpeechRolePath = openaiAudioSpeechPath + "/role"
+
+	// defaultT2AResponseFormat is used when the client does not specify response_format.
+	defaultT2AResponseFormat = "mp3"
+
+	senderTypeUser     string = "USER"     // Content sent by the user.
+	senderTypeBot      string = "BOT"      // Content generated by the model.
+	senderTypeFunction string = "FUNCTION" // Content returned by a previously invoked function/tool.
+
+	// minimaxToolCallStateContextKey stores the in-flight function-call accumulator on the HttpContext so that
+	// OnStreamingResponseBody can reassemble argument fragments split across chunks.
+	minimaxToolCallStateContextKey = "minimaxToolCallState"
+	// minimaxStreamBufferContextKey stores the trailing, not-yet-terminated fragment of an SSE stream so that an
+	// event split across two proxy-wasm chunks can be reassembled before being parsed.
+	minimaxStreamBufferContextKey = "minimaxStreamBuffer"
+	// minimaxIncludeUsageContextKey records whether the incoming request asked for stream_options.include_usage,
+	// so OnStreamingResponseBody knows whether to surface MiniMax's end-of-stream usage in a delta chunk.
+	minimaxIncludeUsageContextKey = "minimaxIncludeUsage"
 
 	// Default bot settings.
 	defaultBotName           string = "MM智能助理"
@@ -35,11 +84,39 @@ const (
 	defaultSenderName        string = "小明"
 )
 
+// MiniMax-specific ApiName values. These are matched against the incoming request path in GetApiName.
+const (
+	ApiNameTextToAudio ApiName = "textToAudio"
+	ApiNameVoiceClone  ApiName = "voiceClone"
+	ApiNameAssistants  ApiName = "assistants"
+	ApiNameThreads     ApiName = "threads"
+	ApiNameFiles       ApiName = "files"
+	ApiNameRetrieval   ApiName = "retrieval"
+
+	ApiNameFineTuningJobs   ApiName = "fineTuningJobs"
+	ApiNameFineTuningEvents ApiName = "fineTuningEvents"
+	ApiNameFineTuningCancel ApiName = "fineTuningCancel"
+
+	ApiNameRoleClassify        ApiName = "roleClassify"
+	ApiNameRoleAudioGeneration ApiName = "roleAudioGeneration"
+)
+
+// TODO(agents): the agent-persona feature (named system-prompt/tool/parameter bundles selectable via an
+// "agent" field or the X-Higress-LLM-Agent header) is not implemented in this provider yet. It requires an
+// `agents []llmAgent` field on the shared ProviderConfig in provider.go, plus YAML parsing wiring for
+// providers[*].agents[*], neither of which exists in this tree; shipping it here without that companion change
+// would reference a ProviderConfig field that doesn't exist. The backlog's "optional preloaded context file per
+// agent" requirement is also unimplemented pending the same wiring. Deferred until ProviderConfig carries the
+// field.
+
 type minimaxProviderInitializer struct {
 }
 
 func (m *minimaxProviderInitializer) ValidateConfig(config ProviderConfig) error {
-	// If using the chat completion Pro API, a group ID must be set.
+	// If using the chat completion Pro API, a group ID must be set. Plain v2 chat completion deployments that
+	// never touch the Assistants/Fine-Tuning/Role* endpoints are not required to set one here; those endpoints
+	// instead validate minimaxGroupId lazily, per request, so a v2-only config isn't rejected at startup for a
+	// requirement it doesn't need.
 	if minimaxApiTypePro == config.minimaxApiType && config.minimaxGroupId == "" {
 		return errors.New(fmt.Sprintf("missing minimaxGroupId in provider config when minimaxApiType is %s", minimaxApiTypePro))
 	}
@@ -66,9 +143,20 @@ func (m *minimaxProvider) GetProviderType() string {
 }
 
 func (m *minimaxProvider) OnRequestHeaders(ctx wrapper.HttpContext, apiName ApiName, log wrapper.Log) (types.Action, error) {
-	if apiName != ApiNameChatCompletion {
+	switch apiName {
+	case ApiNameChatCompletion, ApiNameTextToAudio, ApiNameVoiceClone, ApiNameAssistants, ApiNameThreads, ApiNameFiles, ApiNameRetrieval,
+		ApiNameFineTuningJobs, ApiNameFineTuningEvents, ApiNameFineTuningCancel, ApiNameRoleClassify, ApiNameRoleAudioGeneration:
+	default:
 		return types.ActionContinue, errUnsupportedApiName
 	}
+	switch apiName {
+	case ApiNameAssistants, ApiNameThreads, ApiNameFiles, ApiNameRetrieval,
+		ApiNameFineTuningJobs, ApiNameFineTuningEvents, ApiNameFineTuningCancel:
+		// GroupId is injected into the path in TransformRequestHeaders, including for body-less calls.
+		if m.config.minimaxGroupId == "" {
+			return types.ActionContinue, fmt.Errorf("missing minimaxGroupId in provider config, which is required by the %s API", apiName)
+		}
+	}
 	m.config.handleRequestHeaders(m, ctx, apiName, log)
 	// Delay the header processing to allow changing streaming mode in OnRequestBody
 	return types.HeaderStopIteration, nil
@@ -78,38 +166,402 @@ func (m *minimaxProvider) TransformRequestHeaders(ctx wrapper.HttpContext, apiNa
 	util.OverwriteRequestHostHeader(headers, minimaxDomain)
 	util.OverwriteRequestAuthorizationHeader(headers, "Bearer "+m.config.GetApiTokenInUse(ctx))
 	headers.Del("Content-Length")
+	switch apiName {
+	case ApiNameAssistants, ApiNameThreads, ApiNameFiles, ApiNameRetrieval:
+		m.injectGroupIdQueryParam(headers)
+	case ApiNameFineTuningJobs, ApiNameFineTuningEvents, ApiNameFineTuningCancel:
+		m.rewriteFineTuningPath(headers)
+	}
 }
 
-func (m *minimaxProvider) OnRequestBody(ctx wrapper.HttpContext, apiName ApiName, body []byte, log wrapper.Log) (types.Action, error) {
-	if apiName != ApiNameChatCompletion {
-		return types.ActionContinue, errUnsupportedApiName
+// rewriteFineTuningPath rewrites the OpenAI-compatible /v1/fine_tuning/jobs[...] path onto MiniMax's own
+// Finetune API path and injects GroupId, so that GET/cancel calls (which never reach OnRequestBody) are routed
+// correctly too.
+func (m *minimaxProvider) rewriteFineTuningPath(headers http.Header) {
+	path := headers.Get(":path")
+	if path == "" {
+		return
+	}
+	newPath := strings.Replace(path, openaiFineTuningJobsPath, minimaxFinetuneJobPath, 1)
+	separator := "?"
+	if strings.Contains(newPath, "?") {
+		separator = "&"
 	}
-	if minimaxApiTypePro == m.config.minimaxApiType {
-		// Use chat completion Pro API.
-		return m.handleRequestBodyByChatCompletionPro(body, log)
-	} else {
+	util.OverwriteRequestPathHeader(headers, fmt.Sprintf("%s%sGroupId=%s", newPath, separator, m.config.minimaxGroupId))
+}
+
+// injectGroupIdQueryParam appends the configured MiniMax GroupId as a query parameter, which the Assistants,
+// Files and Retrieval APIs require on every call (including GETs, which never reach OnRequestBody).
+func (m *minimaxProvider) injectGroupIdQueryParam(headers http.Header) {
+	path := headers.Get(":path")
+	if path == "" {
+		return
+	}
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	util.OverwriteRequestPathHeader(headers, fmt.Sprintf("%s%sGroupId=%s", path, separator, m.config.minimaxGroupId))
+}
+
+func (m *minimaxProvider) OnRequestBody(ctx wrapper.HttpContext, apiName ApiName, body []byte, log wrapper.Log) (types.Action, error) {
+	switch apiName {
+	case ApiNameChatCompletion:
+		if minimaxApiTypePro == m.config.minimaxApiType {
+			// Use chat completion Pro API.
+			return m.handleRequestBodyByChatCompletionPro(ctx, body, log)
+		}
 		// Use chat completion V2 API.
 		return m.config.handleRequestBody(m, m.contextCache, ctx, apiName, body, log)
+	case ApiNameTextToAudio:
+		return m.handleRequestBodyByTextToAudio(body, log)
+	case ApiNameVoiceClone:
+		return m.handleRequestBodyByVoiceClone(body, log)
+	case ApiNameAssistants, ApiNameThreads, ApiNameRetrieval:
+		return m.handleRequestBodyByAssistants(body, log)
+	case ApiNameFiles:
+		// Uploads are multipart/form-data; MiniMax's Files API already accepts the same encoding, so the body
+		// is forwarded untouched.
+		return types.ActionContinue, nil
+	case ApiNameFineTuningJobs:
+		return m.handleRequestBodyByFineTuningJobs(body, log)
+	case ApiNameFineTuningEvents, ApiNameFineTuningCancel:
+		// These are GET/empty-body calls; the path rewrite already happened in TransformRequestHeaders.
+		return types.ActionContinue, nil
+	case ApiNameRoleClassify:
+		return m.handleRequestBodyByRoleClassify(body, log)
+	case ApiNameRoleAudioGeneration:
+		return m.handleRequestBodyByRoleAudioGeneration(body, log)
+	default:
+		return types.ActionContinue, errUnsupportedApiName
+	}
+}
+
+// handleRequestBodyByAssistants maps the model field (when present) through the configured model mapping.
+// The rest of MiniMax's Assistants/Threads/Retrieval request schema already mirrors OpenAI's Assistants v2 API.
+func (m *minimaxProvider) handleRequestBodyByAssistants(body []byte, log wrapper.Log) (types.Action, error) {
+	rawModel := gjson.GetBytes(body, "model").String()
+	if rawModel == "" {
+		return types.ActionContinue, nil
+	}
+	mappedModel := getMappedModel(rawModel, m.config.modelMapping, log)
+	modifiedBody, err := sjson.SetBytes(body, "model", mappedModel)
+	if err != nil {
+		return types.ActionContinue, err
+	}
+	return types.ActionContinue, proxywasm.ReplaceHttpRequestBody(modifiedBody)
+}
+
+// rewriteAssistantsResponseIds maps MiniMax's assistant_id/thread_id/run_id response fields onto the generic
+// "id" field that OpenAI Assistants SDKs expect every object to carry.
+func (m *minimaxProvider) rewriteAssistantsResponseIds(body []byte) error {
+	modifiedBody := body
+	changed := false
+	for _, field := range []string{"assistant_id", "thread_id", "run_id"} {
+		idValue := gjson.GetBytes(modifiedBody, field)
+		if !idValue.Exists() {
+			continue
+		}
+		var err error
+		modifiedBody, err = sjson.SetBytes(modifiedBody, "id", idValue.String())
+		if err != nil {
+			return err
+		}
+		changed = true
+	}
+	if changed {
+		return proxywasm.ReplaceHttpResponseBody(modifiedBody)
+	}
+	return nil
+}
+
+// handleRequestBodyByFineTuningJobs translates an OpenAI-compatible fine-tuning job creation request onto
+// MiniMax's Finetune API: training_file becomes file_id and hyperparameters.n_epochs becomes the top-level
+// epochs field.
+func (m *minimaxProvider) handleRequestBodyByFineTuningJobs(body []byte, log wrapper.Log) (types.Action, error) {
+	modifiedBody := body
+	var err error
+	if trainingFile := gjson.GetBytes(modifiedBody, "training_file"); trainingFile.Exists() {
+		if modifiedBody, err = sjson.SetBytes(modifiedBody, "file_id", trainingFile.String()); err != nil {
+			return types.ActionContinue, err
+		}
+		if modifiedBody, err = sjson.DeleteBytes(modifiedBody, "training_file"); err != nil {
+			return types.ActionContinue, err
+		}
+	}
+	if nEpochs := gjson.GetBytes(modifiedBody, "hyperparameters.n_epochs"); nEpochs.Exists() {
+		if modifiedBody, err = sjson.SetBytes(modifiedBody, "epochs", nEpochs.Value()); err != nil {
+			return types.ActionContinue, err
+		}
+		if modifiedBody, err = sjson.DeleteBytes(modifiedBody, "hyperparameters"); err != nil {
+			return types.ActionContinue, err
+		}
+	}
+	if rawModel := gjson.GetBytes(modifiedBody, "model").String(); rawModel != "" {
+		mappedModel := getMappedModel(rawModel, m.config.modelMapping, log)
+		if modifiedBody, err = sjson.SetBytes(modifiedBody, "model", mappedModel); err != nil {
+			return types.ActionContinue, err
+		}
+	}
+	return types.ActionContinue, proxywasm.ReplaceHttpRequestBody(modifiedBody)
+}
+
+// rewriteFineTuningJob adds the OpenAI fine_tuning.job fields to a single MiniMax Finetune job object: file_id is
+// copied into training_file and epochs into hyperparameters.n_epochs. The original MiniMax fields are kept.
+func rewriteFineTuningJob(job []byte) ([]byte, error) {
+	modifiedJob := job
+	var err error
+	if fileId := gjson.GetBytes(modifiedJob, "file_id"); fileId.Exists() {
+		if modifiedJob, err = sjson.SetBytes(modifiedJob, "training_file", fileId.String()); err != nil {
+			return nil, err
+		}
+	}
+	if epochs := gjson.GetBytes(modifiedJob, "epochs"); epochs.Exists() {
+		if modifiedJob, err = sjson.SetBytes(modifiedJob, "hyperparameters.n_epochs", epochs.Value()); err != nil {
+			return nil, err
+		}
+	}
+	return modifiedJob, nil
+}
+
+// rewriteFineTuningJobResponse maps a MiniMax Finetune job response back onto the OpenAI fine_tuning.job shape.
+// ApiNameFineTuningJobs serves create, retrieve, and list alike: a list response (object:"list" with a "data"
+// array) is left as a list and has each of its entries rewritten individually, rather than stamping the whole
+// body as a single job.
+func (m *minimaxProvider) rewriteFineTuningJobResponse(body []byte) error {
+	if data := gjson.GetBytes(body, "data"); data.IsArray() {
+		modifiedBody := body
+		for i, job := range data.Array() {
+			rewrittenJob, err := rewriteFineTuningJob([]byte(job.Raw))
+			if err != nil {
+				return err
+			}
+			if modifiedBody, err = sjson.SetRawBytes(modifiedBody, fmt.Sprintf("data.%d", i), rewrittenJob); err != nil {
+				return err
+			}
+		}
+		return proxywasm.ReplaceHttpResponseBody(modifiedBody)
+	}
+
+	modifiedBody, err := sjson.SetBytes(body, "object", "fine_tuning.job")
+	if err != nil {
+		return err
+	}
+	if modifiedBody, err = rewriteFineTuningJob(modifiedBody); err != nil {
+		return err
+	}
+	return proxywasm.ReplaceHttpResponseBody(modifiedBody)
+}
+
+// minimaxRoleClassifyRequest represents the structure of a MiniMax Role Classification request.
+type minimaxRoleClassifyRequest struct {
+	Model string   `json:"model"`
+	Text  []string `json:"text"`
+}
+
+// minimaxRoleClassifyResponse represents the structure of a MiniMax Role Classification response.
+type minimaxRoleClassifyResponse struct {
+	Results  []minimaxRoleClassifyResult `json:"results"`
+	BaseResp minimaxBaseResp             `json:"base_resp"`
+}
+
+type minimaxRoleClassifyResult struct {
+	Role  string  `json:"role"`
+	Score float64 `json:"score"`
+}
+
+// minimaxRoleAudioRequest extends the T2A request with the character-voice fields Role Audio Generation adds on top.
+type minimaxRoleAudioRequest struct {
+	minimaxTextToAudioRequest
+	CharacterId string `json:"character_id,omitempty"`
+	Emotion     string `json:"emotion,omitempty"`
+}
+
+// handleRequestBodyByRoleClassify translates an OpenAI-compatible /v1/moderations request into MiniMax's
+// Role Classification request. The moderations-style "input" field (string or array of strings) becomes "text".
+func (m *minimaxProvider) handleRequestBodyByRoleClassify(body []byte, log wrapper.Log) (types.Action, error) {
+	if m.config.minimaxGroupId == "" {
+		return types.ActionContinue, errors.New("missing minimaxGroupId in provider config, which is required by the Role Classification API")
+	}
+	model := getMappedModel(gjson.GetBytes(body, "model").String(), m.config.modelMapping, log)
+
+	var text []string
+	input := gjson.GetBytes(body, "input")
+	if input.IsArray() {
+		for _, item := range input.Array() {
+			text = append(text, item.String())
+		}
+	} else if input.Exists() {
+		text = []string{input.String()}
 	}
+
+	minimaxRequest := &minimaxRoleClassifyRequest{
+		Model: model,
+		Text:  text,
+	}
+	_ = util.OverwriteRequestPath(fmt.Sprintf("%s?GroupId=%s", minimaxRoleClassifyPath, m.config.minimaxGroupId))
+	return types.ActionContinue, replaceJsonRequestBody(minimaxRequest, log)
+}
+
+// rewriteRoleClassifyResponse maps a MiniMax Role Classification response onto the OpenAI moderations response
+// shape: each role becomes a category that is flagged when its score is at least 0.5, and the result is flagged
+// when any role is.
+func (m *minimaxProvider) rewriteRoleClassifyResponse(body []byte) error {
+	minimaxResp := &minimaxRoleClassifyResponse{}
+	if err := json.Unmarshal(body, minimaxResp); err != nil {
+		return fmt.Errorf("unable to unmarshal minimax response: %v", err)
+	}
+	if minimaxResp.BaseResp.StatusCode != 0 {
+		return fmt.Errorf("minimax response error, error_code: %d, error_message: %s", minimaxResp.BaseResp.StatusCode, minimaxResp.BaseResp.StatusMsg)
+	}
+	categories := map[string]bool{}
+	categoryScores := map[string]float64{}
+	flagged := false
+	for _, result := range minimaxResp.Results {
+		categories[result.Role] = result.Score >= 0.5
+		categoryScores[result.Role] = result.Score
+		if result.Score >= 0.5 {
+			flagged = true
+		}
+	}
+	response := map[string]interface{}{
+		"model": "minimax-role",
+		"results": []map[string]interface{}{
+			{
+				"flagged":         flagged,
+				"categories":      categories,
+				"category_scores": categoryScores,
+			},
+		},
+	}
+	responseBody, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	return proxywasm.ReplaceHttpResponseBody(responseBody)
+}
+
+// handleRequestBodyByRoleAudioGeneration translates an OpenAI-compatible /v1/audio/speech-style request, extended
+// with character_id/emotion fields, into a MiniMax Role Audio Generation request.
+func (m *minimaxProvider) handleRequestBodyByRoleAudioGeneration(body []byte, log wrapper.Log) (types.Action, error) {
+	if m.config.minimaxGroupId == "" {
+		return types.ActionContinue, errors.New("missing minimaxGroupId in provider config, which is required by the Role Audio Generation API")
+	}
+	model := getMappedModel(gjson.GetBytes(body, "model").String(), m.config.modelMapping, log)
+	speed := gjson.GetBytes(body, "speed").Float()
+	if speed == 0 {
+		speed = 1
+	}
+	format := gjson.GetBytes(body, "response_format").String()
+	if format == "" {
+		format = defaultT2AResponseFormat
+	}
+
+	minimaxRequest := &minimaxRoleAudioRequest{
+		minimaxTextToAudioRequest: minimaxTextToAudioRequest{
+			Model:  model,
+			Text:   gjson.GetBytes(body, "input").String(),
+			Stream: gjson.GetBytes(body, "stream").Bool(),
+			VoiceSetting: minimaxVoiceSetting{
+				VoiceId: gjson.GetBytes(body, "voice").String(),
+				Speed:   speed,
+			},
+			AudioSetting: minimaxAudioSetting{
+				Format: format,
+			},
+		},
+		CharacterId: gjson.GetBytes(body, "character_id").String(),
+		Emotion:     gjson.GetBytes(body, "emotion").String(),
+	}
+	_ = util.OverwriteRequestPath(fmt.Sprintf("%s?GroupId=%s", minimaxRoleAudioPath, m.config.minimaxGroupId))
+	return types.ActionContinue, replaceJsonRequestBody(minimaxRequest, log)
 }
 
 func (m *minimaxProvider) TransformRequestBodyHeaders(ctx wrapper.HttpContext, apiName ApiName, body []byte, headers http.Header, log wrapper.Log) ([]byte, error) {
+	if apiName != ApiNameChatCompletion {
+		// Path rewriting and body translation for the T2A / voice cloning APIs already happened in OnRequestBody.
+		return body, nil
+	}
 	return m.handleRequestBodyByChatCompletionV2(body, headers, log)
 }
 
+// minimaxTextToAudioModelPaths maps an exact (already mapped) model id to the MiniMax T2A path that serves it.
+// Models not listed here fall back to the v2 path; there is no substring or prefix matching, so a model id
+// never gets silently misrouted to a tier it didn't ask for.
+var minimaxTextToAudioModelPaths = map[string]string{
+	"speech-01-pro":        minimaxTextToAudioProPath,
+	"speech-01-pro-240602": minimaxTextToAudioProPath,
+	"speech-02-large":      minimaxTextToAudioLargePath,
+}
+
+// resolveTextToAudioPath picks the T2A tier (v2, pro, or large) to forward a request to, by looking the
+// (already mapped) model id up in minimaxTextToAudioModelPaths.
+func resolveTextToAudioPath(model string) string {
+	if path, ok := minimaxTextToAudioModelPaths[model]; ok {
+		return path
+	}
+	return minimaxTextToAudioPath
+}
+
+// handleRequestBodyByTextToAudio translates an OpenAI-compatible /v1/audio/speech request into a MiniMax T2A
+// request, routed to the v2, pro, or large tier depending on the requested model.
+func (m *minimaxProvider) handleRequestBodyByTextToAudio(body []byte, log wrapper.Log) (types.Action, error) {
+	model := getMappedModel(gjson.GetBytes(body, "model").String(), m.config.modelMapping, log)
+	speed := gjson.GetBytes(body, "speed").Float()
+	if speed == 0 {
+		speed = 1
+	}
+	format := gjson.GetBytes(body, "response_format").String()
+	if format == "" {
+		format = defaultT2AResponseFormat
+	}
+
+	minimaxRequest := &minimaxTextToAudioRequest{
+		Model:  model,
+		Text:   gjson.GetBytes(body, "input").String(),
+		Stream: gjson.GetBytes(body, "stream").Bool(),
+		VoiceSetting: minimaxVoiceSetting{
+			VoiceId: gjson.GetBytes(body, "voice").String(),
+			Speed:   speed,
+		},
+		AudioSetting: minimaxAudioSetting{
+			Format: format,
+		},
+	}
+	_ = util.OverwriteRequestPath(fmt.Sprintf("%s?GroupId=%s", resolveTextToAudioPath(model), m.config.minimaxGroupId))
+	return types.ActionContinue, replaceJsonRequestBody(minimaxRequest, log)
+}
+
+// handleRequestBodyByVoiceClone forwards a voice-cloning request to MiniMax's own API, rewriting the path and
+// injecting GroupId. MiniMax has no OpenAI equivalent for this capability, so the request body is passed through
+// unmodified aside from the model mapping applied to the cloned voice's base model, if present.
+func (m *minimaxProvider) handleRequestBodyByVoiceClone(body []byte, log wrapper.Log) (types.Action, error) {
+	_ = util.OverwriteRequestPath(fmt.Sprintf("%s?GroupId=%s", minimaxVoiceClonePath, m.config.minimaxGroupId))
+	if rawModel := gjson.GetBytes(body, "model").String(); rawModel != "" {
+		mappedModel := getMappedModel(rawModel, m.config.modelMapping, log)
+		if modifiedBody, err := sjson.SetBytes(body, "model", mappedModel); err == nil {
+			return types.ActionContinue, proxywasm.ReplaceHttpRequestBody(modifiedBody)
+		}
+	}
+	return types.ActionContinue, proxywasm.ReplaceHttpRequestBody(body)
+}
+
 // handleRequestBodyByChatCompletionPro processes the request body using the chat completion Pro API.
-func (m *minimaxProvider) handleRequestBodyByChatCompletionPro(body []byte, log wrapper.Log) (types.Action, error) {
+func (m *minimaxProvider) handleRequestBodyByChatCompletionPro(ctx wrapper.HttpContext, body []byte, log wrapper.Log) (types.Action, error) {
 	request := &chatCompletionRequest{}
 	if err := decodeChatCompletionRequest(body, request); err != nil {
 		return types.ActionContinue, err
 	}
-
 	// Map the model and rewrite the request path.
 	request.Model = getMappedModel(request.Model, m.config.modelMapping, log)
+	if err := validateReasoningModelParams(request); err != nil {
+		return types.ActionContinue, err
+	}
+	ctx.SetContext(minimaxIncludeUsageContextKey, gjson.GetBytes(body, "stream_options.include_usage").Bool())
 	_ = util.OverwriteRequestPath(fmt.Sprintf("%s?GroupId=%s", minimaxChatCompletionProPath, m.config.minimaxGroupId))
 
 	if m.config.context == nil {
-		minimaxRequest := m.buildMinimaxChatCompletionV2Request(request, "")
+		minimaxRequest := m.buildMinimaxChatCompletionV2Request(request, "", body)
 		return types.ActionContinue, replaceJsonRequestBody(minimaxRequest, log)
 	}
 
@@ -124,7 +576,7 @@ func (m *minimaxProvider) handleRequestBodyByChatCompletionPro(body []byte, log
 		// Since minimaxChatCompletionV2 (format consistent with OpenAI) and minimaxChatCompletionPro (different format from OpenAI) have different logic for insertHttpContextMessage, we cannot unify them within one provider.
 		// For minimaxChatCompletionPro, we need to manually handle context messages.
 		// minimaxChatCompletionV2 uses the default defaultInsertHttpContextMessage method to insert context messages.
-		minimaxRequest := m.buildMinimaxChatCompletionV2Request(request, content)
+		minimaxRequest := m.buildMinimaxChatCompletionV2Request(request, content, body)
 		if err := replaceJsonRequestBody(minimaxRequest, log); err != nil {
 			util.ErrorHandler("ai-proxy.minimax.insert_ctx_failed", fmt.Errorf("failed to replace Request body: %v", err))
 		}
@@ -135,12 +587,84 @@ func (m *minimaxProvider) handleRequestBodyByChatCompletionPro(body []byte, log
 	return types.ActionContinue, err
 }
 
+// minimaxReasoningModelMarkers identifies abab/o1-style reasoning models, which reject several sampling
+// parameters the same way OpenAI's o1 models do.
+var minimaxReasoningModelMarkers = []string{"o1", "reasoning"}
+
+func isMinimaxReasoningModel(model string) bool {
+	lower := strings.ToLower(model)
+	for _, marker := range minimaxReasoningModelMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// errUnsupportedParamForModel mirrors the OpenAI go client's o1-model validation: a handful of sampling and
+// streaming parameters are rejected outright when the target model is a MiniMax reasoning-class model.
+func errUnsupportedParamForModel(param, model string) error {
+	return fmt.Errorf("%s is not supported with model %s", param, model)
+}
+
+// validateReasoningModelParams rejects parameters that MiniMax reasoning-class models do not support,
+// before the request ever leaves the gateway.
+func validateReasoningModelParams(request *chatCompletionRequest) error {
+	if !isMinimaxReasoningModel(request.Model) {
+		return nil
+	}
+	if request.Temperature != 0 && request.Temperature != 1 {
+		return errUnsupportedParamForModel("temperature", request.Model)
+	}
+	if request.TopP != 0 && request.TopP != 1 {
+		return errUnsupportedParamForModel("top_p", request.Model)
+	}
+	if request.PresencePenalty != 0 {
+		return errUnsupportedParamForModel("presence_penalty", request.Model)
+	}
+	if request.FrequencyPenalty != 0 {
+		return errUnsupportedParamForModel("frequency_penalty", request.Model)
+	}
+	if request.Stream {
+		return errUnsupportedParamForModel("stream", request.Model)
+	}
+	return nil
+}
+
+// validateReasoningModelParamsRawBody is the chatcompletion_v2 counterpart of validateReasoningModelParams: since
+// the V2 protocol is handled as raw JSON rather than a decoded chatCompletionRequest, the same parameters are
+// read directly off the body via gjson.
+func validateReasoningModelParamsRawBody(model string, body []byte) error {
+	if !isMinimaxReasoningModel(model) {
+		return nil
+	}
+	if temperature := gjson.GetBytes(body, "temperature"); temperature.Exists() && temperature.Float() != 0 && temperature.Float() != 1 {
+		return errUnsupportedParamForModel("temperature", model)
+	}
+	if topP := gjson.GetBytes(body, "top_p"); topP.Exists() && topP.Float() != 0 && topP.Float() != 1 {
+		return errUnsupportedParamForModel("top_p", model)
+	}
+	if gjson.GetBytes(body, "presence_penalty").Float() != 0 {
+		return errUnsupportedParamForModel("presence_penalty", model)
+	}
+	if gjson.GetBytes(body, "frequency_penalty").Float() != 0 {
+		return errUnsupportedParamForModel("frequency_penalty", model)
+	}
+	if gjson.GetBytes(body, "stream").Bool() {
+		return errUnsupportedParamForModel("stream", model)
+	}
+	return nil
+}
+
 // handleRequestBodyByChatCompletionV2 processes the request body using the chat completion V2 API.
 func (m *minimaxProvider) handleRequestBodyByChatCompletionV2(body []byte, headers http.Header, log wrapper.Log) ([]byte, error) {
 	util.OverwriteRequestPathHeader(headers, minimaxChatCompletionV2Path)
 
 	rawModel := gjson.GetBytes(body, "model").String()
 	mappedModel := getMappedModel(rawModel, m.config.modelMapping, log)
+	if err := validateReasoningModelParamsRawBody(mappedModel, body); err != nil {
+		return nil, err
+	}
 	return sjson.SetBytes(body, "model", mappedModel)
 }
 
@@ -150,6 +674,12 @@ func (m *minimaxProvider) OnResponseHeaders(ctx wrapper.HttpContext, apiName Api
 		ctx.DontReadResponseBody()
 		return types.ActionContinue, nil
 	}
+	switch apiName {
+	case ApiNameTextToAudio, ApiNameVoiceClone, ApiNameAssistants, ApiNameThreads, ApiNameFiles, ApiNameRetrieval,
+		ApiNameFineTuningJobs, ApiNameFineTuningEvents, ApiNameFineTuningCancel, ApiNameRoleClassify, ApiNameRoleAudioGeneration:
+		_ = proxywasm.RemoveHttpResponseHeader("Content-Length")
+		return types.ActionContinue, nil
+	}
 	// Skip OnStreamingResponseBody() and OnResponseBody() when the model corresponds to the chat completion V2 interface.
 	if minimaxApiTypePro != m.config.minimaxApiType {
 		ctx.DontReadResponseBody()
@@ -159,30 +689,77 @@ func (m *minimaxProvider) OnResponseHeaders(ctx wrapper.HttpContext, apiName Api
 	return types.ActionContinue, nil
 }
 
+// splitMinimaxStreamEvents splits buffered plus newly-arrived chunk bytes into complete "\n\n"-delimited SSE
+// events, returning any trailing, not-yet-terminated fragment as pending so the caller can prefix it onto the
+// next chunk. On the last chunk, a non-blank trailing fragment is flushed as a final event instead of being
+// held back, since no further chunk will arrive to complete it.
+func splitMinimaxStreamEvents(buffered string, chunk []byte, isLastChunk bool) (events []string, pending string) {
+	pending = buffered + string(chunk)
+	for {
+		idx := strings.Index(pending, "\n\n")
+		if idx == -1 {
+			break
+		}
+		events = append(events, pending[:idx])
+		pending = pending[idx+2:]
+	}
+	if isLastChunk {
+		if strings.TrimSpace(pending) != "" {
+			events = append(events, pending)
+		}
+		pending = ""
+	}
+	return events, pending
+}
+
+// minimaxShouldIncludeUsage reports whether a streamed chunk's usage should be forwarded to the client: only
+// when the request opted in via stream_options.include_usage and MiniMax actually reported a non-zero total,
+// since MiniMax sends a zeroed usage object on every non-final chunk.
+func minimaxShouldIncludeUsage(includeUsage bool, totalTokens int64) bool {
+	return includeUsage && totalTokens != 0
+}
+
 // OnStreamingResponseBody handles streaming response chunks from the Minimax service only for requests using the OpenAI protocol and corresponding to the chat completion Pro API.
+// Since a MiniMax SSE event isn't guaranteed to land entirely within a single proxy-wasm chunk, the trailing,
+// not-yet-terminated fragment of each chunk is buffered on the HttpContext and prefixed onto the next one.
+// Text-to-audio and role-audio streams are handled here too, by handleTextToAudioStreamingResponseBody.
 func (m *minimaxProvider) OnStreamingResponseBody(ctx wrapper.HttpContext, name ApiName, chunk []byte, isLastChunk bool, log wrapper.Log) ([]byte, error) {
-	if isLastChunk || len(chunk) == 0 {
-		return nil, nil
+	if name == ApiNameTextToAudio || name == ApiNameRoleAudioGeneration {
+		return m.handleTextToAudioStreamingResponseBody(chunk, isLastChunk, log)
 	}
 	// Sample event response:
 	// data: {"created":1689747645,"model":"abab6.5s-chat","reply":"","choices":[{"messages":[{"sender_type":"BOT","sender_name":"MM智能助理","text":"am from China."}]}],"output_sensitive":false}
 
 	// Sample end event response:
 	// data: {"created":1689747645,"model":"abab6.5s-chat","reply":"I am from China.","choices":[{"finish_reason":"stop","messages":[{"sender_type":"BOT","sender_name":"MM智能助理","text":"I am from China."}]}],"usage":{"total_tokens":187},"input_sensitive":false,"output_sensitive":false,"id":"0106b3bc9fd844a9f3de1aa06004e2ab","base_resp":{"status_code":0,"status_msg":""}}
+	buffered, _ := ctx.GetContext(minimaxStreamBufferContextKey).(string)
+	events, pending := splitMinimaxStreamEvents(buffered, chunk, isLastChunk)
+	ctx.SetContext(minimaxStreamBufferContextKey, pending)
+
+	includeUsage, _ := ctx.GetContext(minimaxIncludeUsageContextKey).(bool)
 	responseBuilder := &strings.Builder{}
-	lines := strings.Split(string(chunk), "\n")
-	for _, data := range lines {
-		if len(data) < 6 {
-			// Ignore blank line or improperly formatted lines.
+	for _, event := range events {
+		data := strings.TrimSpace(event)
+		if !strings.HasPrefix(data, streamDataItemKey) {
+			// Ignore blank or improperly formatted events.
+			continue
+		}
+		data = strings.TrimSpace(strings.TrimPrefix(data, streamDataItemKey))
+		if data == "" {
 			continue
 		}
-		data = data[6:]
 		var minimaxResp minimaxChatCompletionV2Resp
 		if err := json.Unmarshal([]byte(data), &minimaxResp); err != nil {
 			log.Errorf("unable to unmarshal minimax response: %v", err)
 			continue
 		}
-		response := m.responseV2ToOpenAI(&minimaxResp)
+		response := m.rewriteFunctionCallDelta(ctx, &minimaxResp)
+		if response == nil {
+			response = m.responseV2ToOpenAI(&minimaxResp)
+		}
+		if !minimaxShouldIncludeUsage(includeUsage, minimaxResp.Usage.TotalTokens) {
+			response.Usage = usage{}
+		}
 		responseBody, err := json.Marshal(response)
 		if err != nil {
 			log.Errorf("unable to marshal response: %v", err)
@@ -190,13 +767,64 @@ func (m *minimaxProvider) OnStreamingResponseBody(ctx wrapper.HttpContext, name
 		}
 		m.appendResponse(responseBuilder, string(responseBody))
 	}
+	if isLastChunk {
+		responseBuilder.WriteString(fmt.Sprintf("%s [DONE]\n\n", streamDataItemKey))
+	}
 	modifiedResponseChunk := responseBuilder.String()
 	log.Debugf("=== modified response chunk: %s", modifiedResponseChunk)
 	return []byte(modifiedResponseChunk), nil
 }
 
+// handleTextToAudioStreamingResponseBody decodes the hex-encoded audio chunks emitted by the MiniMax T2A streaming
+// API and forwards the raw audio bytes, matching the binary chunk stream an OpenAI /v1/audio/speech client expects.
+func (m *minimaxProvider) handleTextToAudioStreamingResponseBody(chunk []byte, isLastChunk bool, log wrapper.Log) ([]byte, error) {
+	if len(chunk) == 0 {
+		return nil, nil
+	}
+	// Sample event response:
+	// data: {"data":{"audio":"68656c6c6f","status":1},"extra_info":null}
+	audioBuffer := &bytes.Buffer{}
+	for _, line := range strings.Split(string(chunk), "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, streamDataItemKey) {
+			continue
+		}
+		data := strings.TrimSpace(strings.TrimPrefix(line, streamDataItemKey))
+		var resp minimaxTextToAudioStreamResponse
+		if err := json.Unmarshal([]byte(data), &resp); err != nil {
+			log.Errorf("unable to unmarshal minimax t2a stream response: %v", err)
+			continue
+		}
+		if resp.Data.Audio == "" {
+			continue
+		}
+		audioBytes, err := hex.DecodeString(resp.Data.Audio)
+		if err != nil {
+			log.Errorf("unable to decode minimax t2a audio chunk: %v", err)
+			continue
+		}
+		audioBuffer.Write(audioBytes)
+	}
+	return audioBuffer.Bytes(), nil
+}
+
 // OnResponseBody handles the final response body from the Minimax service only for requests using the OpenAI protocol and corresponding to the chat completion Pro API.
 func (m *minimaxProvider) OnResponseBody(ctx wrapper.HttpContext, apiName ApiName, body []byte, log wrapper.Log) (types.Action, error) {
+	// Responses of the T2A, voice cloning, Assistants, Fine-Tuning and Role* endpoints are handled here too.
+	if apiName == ApiNameTextToAudio || apiName == ApiNameVoiceClone || apiName == ApiNameRoleAudioGeneration {
+		// Non-streaming T2A/role-audio responses and voice cloning responses are already in their final form; forward as-is.
+		return types.ActionContinue, nil
+	}
+	switch apiName {
+	case ApiNameAssistants, ApiNameThreads, ApiNameFiles, ApiNameRetrieval:
+		return types.ActionContinue, m.rewriteAssistantsResponseIds(body)
+	case ApiNameFineTuningJobs:
+		return types.ActionContinue, m.rewriteFineTuningJobResponse(body)
+	case ApiNameFineTuningEvents, ApiNameFineTuningCancel:
+		return types.ActionContinue, nil
+	case ApiNameRoleClassify:
+		return types.ActionContinue, m.rewriteRoleClassifyResponse(body)
+	}
 	minimaxResp := &minimaxChatCompletionV2Resp{}
 	if err := json.Unmarshal(body, minimaxResp); err != nil {
 		return types.ActionContinue, fmt.Errorf("unable to unmarshal minimax response: %v", err)
@@ -219,13 +847,31 @@ type minimaxChatCompletionV2Request struct {
 	Messages          []minimaxMessage        `json:"messages"`
 	BotSettings       []minimaxBotSetting     `json:"bot_setting"`
 	ReplyConstraints  minimaxReplyConstraints `json:"reply_constraints"`
+	Functions         []minimaxFunction       `json:"functions,omitempty"`
+	FunctionCall      interface{}             `json:"function_call,omitempty"`
+	WebSearch         bool                    `json:"web_search,omitempty"`
+	ReasoningEffort   string                  `json:"reasoning_effort,omitempty"`
 }
 
 // minimaxMessage represents a message in the conversation.
 type minimaxMessage struct {
-	SenderType string `json:"sender_type"`
-	SenderName string `json:"sender_name"`
-	Text       string `json:"text"`
+	SenderType   string               `json:"sender_type"`
+	SenderName   string               `json:"sender_name"`
+	Text         string               `json:"text"`
+	FunctionCall *minimaxFunctionCall `json:"function_call,omitempty"`
+}
+
+// minimaxFunction describes a callable function/tool, translated from an OpenAI tool definition.
+type minimaxFunction struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	Parameters  interface{} `json:"parameters,omitempty"`
+}
+
+// minimaxFunctionCall represents a function invocation requested by the model, or the result of one supplied back to it.
+type minimaxFunctionCall struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
 }
 
 // minimaxBotSetting represents the bot's settings.
@@ -236,8 +882,54 @@ type minimaxBotSetting struct {
 
 // minimaxReplyConstraints represents requirements for model replies.
 type minimaxReplyConstraints struct {
-	SenderType string `json:"sender_type"`
-	SenderName string `json:"sender_name"`
+	SenderType string        `json:"sender_type"`
+	SenderName string        `json:"sender_name"`
+	Glyph      *minimaxGlyph `json:"glyph,omitempty"`
+}
+
+// minimaxGlyph constrains the reply to match a given output template, e.g. raw/json/markdown.
+type minimaxGlyph struct {
+	Type           string `json:"type"`
+	RawGlyph       string `json:"raw_glyph,omitempty"`
+	JsonProperties string `json:"json_properties,omitempty"`
+}
+
+// minimaxTextToAudioRequest represents a MiniMax T2A v2 request, translated from OpenAI's /v1/audio/speech schema.
+type minimaxTextToAudioRequest struct {
+	Model         string                `json:"model"`
+	Text          string                `json:"text"`
+	Stream        bool                  `json:"stream,omitempty"`
+	VoiceSetting  minimaxVoiceSetting   `json:"voice_setting"`
+	AudioSetting  minimaxAudioSetting   `json:"audio_setting,omitempty"`
+	TimberWeights []minimaxTimberWeight `json:"timber_weights,omitempty"`
+}
+
+// minimaxVoiceSetting selects and tunes the voice used for synthesis.
+type minimaxVoiceSetting struct {
+	VoiceId string  `json:"voice_id,omitempty"`
+	Speed   float64 `json:"speed,omitempty"`
+}
+
+// minimaxAudioSetting controls the encoding of the generated audio.
+type minimaxAudioSetting struct {
+	SampleRate int    `json:"sample_rate,omitempty"`
+	Format     string `json:"format,omitempty"`
+}
+
+// minimaxTimberWeight mixes multiple cloned voices together by weight in a T2A request. The OpenAI-compatible
+// translation in handleRequestBodyByTextToAudio never sets TimberWeights.
+type minimaxTimberWeight struct {
+	VoiceId string `json:"voice_id"`
+	Weight  int    `json:"weight"`
+}
+
+// minimaxTextToAudioStreamResponse represents one SSE event emitted by the MiniMax T2A streaming API.
+type minimaxTextToAudioStreamResponse struct {
+	Data struct {
+		Audio  string `json:"audio"`
+		Status int    `json:"status"`
+	} `json:"data"`
+	BaseResp minimaxBaseResp `json:"base_resp,omitempty"`
 }
 
 // minimaxChatCompletionV2Resp represents the structure of a Minimax Chat Completion V2 response.
@@ -307,7 +999,10 @@ func (m *minimaxProvider) setBotSettings(request *minimaxChatCompletionV2Request
 	}
 }
 
-func (m *minimaxProvider) buildMinimaxChatCompletionV2Request(request *chatCompletionRequest, botSettingContent string) *minimaxChatCompletionV2Request {
+// buildMinimaxChatCompletionV2Request builds the MiniMax-bound request from the decoded OpenAI-style request.
+// rawBody is also consulted directly for fields chatCompletionRequest doesn't model, such as the o1-style
+// max_completion_tokens and MiniMax-specific reply_constraints.glyph / web_search knobs.
+func (m *minimaxProvider) buildMinimaxChatCompletionV2Request(request *chatCompletionRequest, botSettingContent string, rawBody []byte) *minimaxChatCompletionV2Request {
 	var messages []minimaxMessage
 	var botSetting []minimaxBotSetting
 	var botName string
@@ -328,17 +1023,32 @@ func (m *minimaxProvider) buildMinimaxChatCompletionV2Request(request *chatCompl
 				Content: message.StringContent(),
 			})
 		case roleAssistant:
-			messages = append(messages, minimaxMessage{
+			minimaxMsg := minimaxMessage{
 				SenderType: senderTypeBot,
 				SenderName: determineName(message.Name, defaultBotName),
 				Text:       message.StringContent(),
-			})
+			}
+			// The chatcompletion_pro protocol only carries a single function call per assistant turn, so
+			// an OpenAI message with multiple tool_calls can only be represented by its first entry.
+			if len(message.ToolCalls) > 0 {
+				minimaxMsg.FunctionCall = &minimaxFunctionCall{
+					Name:      message.ToolCalls[0].Function.Name,
+					Arguments: message.ToolCalls[0].Function.Arguments,
+				}
+			}
+			messages = append(messages, minimaxMsg)
 		case roleUser:
 			messages = append(messages, minimaxMessage{
 				SenderType: senderTypeUser,
 				SenderName: determineName(message.Name, defaultSenderName),
 				Text:       message.StringContent(),
 			})
+		case roleTool:
+			messages = append(messages, minimaxMessage{
+				SenderType: senderTypeFunction,
+				SenderName: determineName(message.Name, defaultBotName),
+				Text:       message.StringContent(),
+			})
 		}
 	}
 
@@ -346,36 +1056,154 @@ func (m *minimaxProvider) buildMinimaxChatCompletionV2Request(request *chatCompl
 		SenderType: senderTypeBot,
 		SenderName: determineName(botName, defaultBotName),
 	}
+	if glyphType := gjson.GetBytes(rawBody, "reply_constraints.glyph.type").String(); glyphType != "" {
+		replyConstraints.Glyph = &minimaxGlyph{
+			Type:           glyphType,
+			RawGlyph:       gjson.GetBytes(rawBody, "reply_constraints.glyph.raw_glyph").String(),
+			JsonProperties: gjson.GetBytes(rawBody, "reply_constraints.glyph.json_properties").String(),
+		}
+	}
+
+	// max_completion_tokens is the o1-style successor to max_tokens and takes priority when the
+	// caller sends both, falling back to max_tokens for callers still on the older field.
+	tokensToGenerate := int(gjson.GetBytes(rawBody, "max_completion_tokens").Int())
+	if tokensToGenerate == 0 {
+		tokensToGenerate = request.MaxTokens
+	}
+
 	result := &minimaxChatCompletionV2Request{
 		Model:             request.Model,
 		Stream:            request.Stream,
-		TokensToGenerate:  int64(request.MaxTokens),
+		TokensToGenerate:  int64(tokensToGenerate),
 		Temperature:       request.Temperature,
 		TopP:              request.TopP,
-		MaskSensitiveInfo: true,
+		MaskSensitiveInfo: !gjson.GetBytes(rawBody, "mask_sensitive_info").Exists() || gjson.GetBytes(rawBody, "mask_sensitive_info").Bool(),
 		Messages:          messages,
 		BotSettings:       botSetting,
 		ReplyConstraints:  replyConstraints,
+		WebSearch:         gjson.GetBytes(rawBody, "web_search").Bool(),
+		ReasoningEffort:   gjson.GetBytes(rawBody, "reasoning_effort").String(),
+	}
+	if len(request.Tools) > 0 {
+		result.Functions = make([]minimaxFunction, 0, len(request.Tools))
+		for _, t := range request.Tools {
+			result.Functions = append(result.Functions, minimaxFunction{
+				Name:        t.Function.Name,
+				Description: t.Function.Description,
+				Parameters:  t.Function.Parameters,
+			})
+		}
+		result.FunctionCall = convertToolChoiceToMinimaxFunctionCall(request.ToolChoice)
 	}
 
 	m.setBotSettings(result, botSettingContent)
 	return result
 }
 
+// convertToolChoiceToMinimaxFunctionCall maps an OpenAI tool_choice value ("auto", "none", or
+// {"type":"function","function":{"name":...}}) onto the equivalent MiniMax function_call value.
+func convertToolChoiceToMinimaxFunctionCall(toolChoice interface{}) interface{} {
+	switch v := toolChoice.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		if fn, ok := v["function"].(map[string]interface{}); ok {
+			return map[string]interface{}{"name": fn["name"]}
+		}
+	}
+	return nil
+}
+
+// minimaxToolCallState tracks the single in-flight function call of a streaming chatcompletion_pro response so that
+// argument fragments spread across multiple chunks can be re-emitted as one consistently-indexed OpenAI tool call.
+type minimaxToolCallState struct {
+	id       string
+	index    int
+	nameSent bool
+}
+
+// rewriteFunctionCallDelta re-emits a MiniMax streaming function_call fragment as an OpenAI delta.tool_calls chunk.
+// It returns nil when the chunk carries no function call, so the caller can fall back to the regular text path.
+func (m *minimaxProvider) rewriteFunctionCallDelta(ctx wrapper.HttpContext, response *minimaxChatCompletionV2Resp) *chatCompletionResponse {
+	var functionCall *minimaxFunctionCall
+	for _, choice := range response.Choices {
+		for _, message := range choice.Messages {
+			if message.FunctionCall != nil {
+				functionCall = message.FunctionCall
+			}
+		}
+	}
+	if functionCall == nil {
+		return nil
+	}
+
+	state, _ := ctx.GetContext(minimaxToolCallStateContextKey).(*minimaxToolCallState)
+	if state == nil {
+		state = &minimaxToolCallState{id: "call_0", index: 0}
+		ctx.SetContext(minimaxToolCallStateContextKey, state)
+	}
+	name := ""
+	if !state.nameSent {
+		name = functionCall.Name
+		state.nameSent = true
+	}
+
+	return &chatCompletionResponse{
+		Id:      response.Id,
+		Object:  objectChatCompletionChunk,
+		Created: response.Created,
+		Model:   response.Model,
+		Choices: []chatCompletionChoice{
+			{
+				Index: 0,
+				Delta: &chatMessage{
+					Role: roleAssistant,
+					ToolCalls: []toolCall{
+						{
+							Index: &state.index,
+							Id:    state.id,
+							Type:  "function",
+							Function: toolCallFunction{
+								Name:      name,
+								Arguments: functionCall.Arguments,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func (m *minimaxProvider) responseV2ToOpenAI(response *minimaxChatCompletionV2Resp) *chatCompletionResponse {
 	var choices []chatCompletionChoice
 	messageIndex := 0
 	for _, choice := range response.Choices {
 		for _, message := range choice.Messages {
-			message := &chatMessage{
+			finishReason := choice.FinishReason
+			chatMsg := &chatMessage{
 				Name:    message.SenderName,
 				Role:    roleAssistant,
 				Content: message.Text,
 			}
+			if message.FunctionCall != nil {
+				chatMsg.Content = ""
+				chatMsg.ToolCalls = []toolCall{
+					{
+						Id:   "call_0",
+						Type: "function",
+						Function: toolCallFunction{
+							Name:      message.FunctionCall.Name,
+							Arguments: message.FunctionCall.Arguments,
+						},
+					},
+				}
+				finishReason = "tool_calls"
+			}
 			choices = append(choices, chatCompletionChoice{
-				FinishReason: choice.FinishReason,
+				FinishReason: finishReason,
 				Index:        messageIndex,
-				Message:      message,
+				Message:      chatMsg,
 			})
 			messageIndex++
 		}
@@ -400,5 +1228,42 @@ func (m *minimaxProvider) GetApiName(path string) ApiName {
 	if strings.Contains(path, minimaxChatCompletionV2Path) || strings.Contains(path, minimaxChatCompletionProPath) {
 		return ApiNameChatCompletion
 	}
+	// Checked before the plain text-to-speech match below, since openaiAudioSpeechRolePath contains
+	// openaiAudioSpeechPath as a prefix.
+	if strings.Contains(path, openaiAudioSpeechRolePath) || strings.Contains(path, minimaxRoleAudioPath) {
+		return ApiNameRoleAudioGeneration
+	}
+	if strings.Contains(path, openaiAudioSpeechPath) || strings.Contains(path, minimaxTextToAudioPath) ||
+		strings.Contains(path, minimaxTextToAudioProPath) || strings.Contains(path, minimaxTextToAudioLargePath) {
+		return ApiNameTextToAudio
+	}
+	if strings.Contains(path, minimaxVoiceClonePath) {
+		return ApiNameVoiceClone
+	}
+	if strings.Contains(path, openaiThreadsPath) {
+		return ApiNameThreads
+	}
+	if strings.Contains(path, openaiAssistantsPath) {
+		return ApiNameAssistants
+	}
+	if strings.Contains(path, openaiFilesPath) {
+		return ApiNameFiles
+	}
+	if strings.Contains(path, openaiRetrievalPath) {
+		return ApiNameRetrieval
+	}
+	if strings.Contains(path, openaiFineTuningJobsPath) {
+		switch {
+		case strings.Contains(path, "/events"):
+			return ApiNameFineTuningEvents
+		case strings.Contains(path, "/cancel"):
+			return ApiNameFineTuningCancel
+		default:
+			return ApiNameFineTuningJobs
+		}
+	}
+	if strings.Contains(path, openaiModerationsPath) {
+		return ApiNameRoleClassify
+	}
 	return ""
 }
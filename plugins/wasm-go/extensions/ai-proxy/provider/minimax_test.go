@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestSplitMinimaxStreamEvents(t *testing.T) {
+	cases := []struct {
+		name        string
+		buffered    string
+		chunk       string
+		isLastChunk bool
+		wantEvents  []string
+		wantPending string
+	}{
+		{
+			name:        "single complete event",
+			buffered:    "",
+			chunk:       "data: {\"a\":1}\n\n",
+			isLastChunk: false,
+			wantEvents:  []string{"data: {\"a\":1}"},
+			wantPending: "",
+		},
+		{
+			name:        "event split across chunks is buffered until the terminator arrives",
+			buffered:    "data: {\"a\":",
+			chunk:       "1}\n\n",
+			isLastChunk: false,
+			wantEvents:  []string{"data: {\"a\":1}"},
+			wantPending: "",
+		},
+		{
+			name:        "trailing partial fragment with no terminator is held as pending",
+			buffered:    "",
+			chunk:       "data: {\"a\":1}\n\ndata: {\"a\":2",
+			isLastChunk: false,
+			wantEvents:  []string{"data: {\"a\":1}"},
+			wantPending: "data: {\"a\":2",
+		},
+		{
+			name:        "last chunk flushes a non-blank trailing fragment instead of buffering it",
+			buffered:    "",
+			chunk:       "data: {\"a\":1}\n\ndata: {\"a\":2}",
+			isLastChunk: true,
+			wantEvents:  []string{"data: {\"a\":1}", "data: {\"a\":2}"},
+			wantPending: "",
+		},
+		{
+			name:        "last chunk drops a blank trailing fragment",
+			buffered:    "",
+			chunk:       "data: {\"a\":1}\n\n   ",
+			isLastChunk: true,
+			wantEvents:  []string{"data: {\"a\":1}"},
+			wantPending: "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			events, pending := splitMinimaxStreamEvents(tc.buffered, []byte(tc.chunk), tc.isLastChunk)
+			if !reflect.DeepEqual(events, tc.wantEvents) {
+				t.Errorf("events = %#v, want %#v", events, tc.wantEvents)
+			}
+			if pending != tc.wantPending {
+				t.Errorf("pending = %q, want %q", pending, tc.wantPending)
+			}
+		})
+	}
+}
+
+func TestMinimaxShouldIncludeUsage(t *testing.T) {
+	cases := []struct {
+		name         string
+		includeUsage bool
+		totalTokens  int64
+		want         bool
+	}{
+		{"not requested, non-zero total", false, 187, false},
+		{"requested, zero total on a non-final chunk", true, 0, false},
+		{"requested, non-zero total on the final chunk", true, 187, true},
+		{"not requested, zero total", false, 0, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := minimaxShouldIncludeUsage(tc.includeUsage, tc.totalTokens); got != tc.want {
+				t.Errorf("minimaxShouldIncludeUsage(%v, %d) = %v, want %v", tc.includeUsage, tc.totalTokens, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMinimaxStreamDoneSentinelFormat(t *testing.T) {
+	// OnStreamingResponseBody builds its closing sentinel with this same format expression once isLastChunk is
+	// true; check that the expression yields the exact bytes SSE clients look for.
+	got := fmt.Sprintf("%s [DONE]\n\n", streamDataItemKey)
+	want := "data: [DONE]\n\n"
+	if got != want {
+		t.Errorf("done sentinel = %q, want %q", got, want)
+	}
+}